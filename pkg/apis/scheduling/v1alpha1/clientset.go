@@ -0,0 +1,128 @@
+package v1alpha1
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// addToSchemeOnce registers PodGroup/PodGroupList with client-go's shared
+// scheme the first time a client is built. Without this, scheme.Scheme has
+// no GroupVersionKind for our types and the REST client's encode path fails
+// on every write with "no kind is registered for the type v1alpha1.PodGroup".
+var addToSchemeOnce sync.Once
+
+// PodGroupInterface has methods to work with PodGroup resources.
+type PodGroupInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*PodGroup, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*PodGroupList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Update(ctx context.Context, podGroup *PodGroup, opts metav1.UpdateOptions) (*PodGroup, error)
+	UpdateStatus(ctx context.Context, podGroup *PodGroup, opts metav1.UpdateOptions) (*PodGroup, error)
+}
+
+type podGroups struct {
+	client rest.Interface
+	ns     string
+}
+
+func (c *podGroups) Get(ctx context.Context, name string, opts metav1.GetOptions) (*PodGroup, error) {
+	result := &PodGroup{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) List(ctx context.Context, opts metav1.ListOptions) (*PodGroupList, error) {
+	result := &PodGroupList{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *podGroups) Update(ctx context.Context, podGroup *PodGroup, opts metav1.UpdateOptions) (*PodGroup, error) {
+	result := &PodGroup{}
+	err := c.client.Put().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(podGroup.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) UpdateStatus(ctx context.Context, podGroup *PodGroup, opts metav1.UpdateOptions) (*PodGroup, error) {
+	result := &PodGroup{}
+	err := c.client.Put().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(podGroup.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// SchedulingV1alpha1Interface exposes the scheduling.nthu.io/v1alpha1 client.
+type SchedulingV1alpha1Interface interface {
+	PodGroups(namespace string) PodGroupInterface
+}
+
+// SchedulingV1alpha1Client is a client for the scheduling.nthu.io/v1alpha1 API group.
+type SchedulingV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+// PodGroups returns a PodGroupInterface scoped to namespace.
+func (c *SchedulingV1alpha1Client) PodGroups(namespace string) PodGroupInterface {
+	return &podGroups{client: c.restClient, ns: namespace}
+}
+
+// NewForConfig creates a new SchedulingV1alpha1Client from the given config.
+func NewForConfig(c *rest.Config) (*SchedulingV1alpha1Client, error) {
+	addToSchemeOnce.Do(func() {
+		utilruntime.Must(AddToScheme(scheme.Scheme))
+	})
+
+	config := *c
+	config.GroupVersion = &SchemeGroupVersion
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.NewCodecFactory(scheme.Scheme).WithoutConversion()
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	restClient, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &SchedulingV1alpha1Client{restClient: restClient}, nil
+}
@@ -0,0 +1,92 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodGroupPhase is the phase of a PodGroup, reported in its status.
+type PodGroupPhase string
+
+const (
+	// PodGroupPending means the PodGroup has been accepted by the system,
+	// but fewer than minMember of its pods have been created yet.
+	PodGroupPending PodGroupPhase = "Pending"
+	// PodGroupPreScheduling means at least minMember of the PodGroup's pods
+	// exist and are waiting for minMember of them to be admitted together.
+	PodGroupPreScheduling PodGroupPhase = "PreScheduling"
+	// PodGroupScheduling means minMember pods of the PodGroup have been
+	// admitted and are being bound to nodes.
+	PodGroupScheduling PodGroupPhase = "Scheduling"
+	// PodGroupRunning means minMember pods of the PodGroup are running.
+	PodGroupRunning PodGroupPhase = "Running"
+	// PodGroupFinished means at least minMember of the PodGroup's pods have
+	// completed successfully.
+	PodGroupFinished PodGroupPhase = "Finished"
+	// PodGroupFailed means at least one of the PodGroup's pods failed and
+	// fewer than minMember of them are running or have succeeded.
+	PodGroupFailed PodGroupPhase = "Failed"
+)
+
+// PodGroupSpec describes how a PodGroup should be admitted.
+type PodGroupSpec struct {
+	// MinMember is the minimum number of pods to be scheduled together.
+	// +kubebuilder:validation:Minimum=1
+	MinMember int32 `json:"minMember"`
+
+	// MinResources is the minimum amount of cluster resources that must be
+	// free before any pod of the group is admitted. Nil means no resource
+	// requirement is enforced beyond MinMember.
+	// +optional
+	MinResources v1.ResourceList `json:"minResources,omitempty"`
+
+	// ScheduleTimeoutSeconds bounds how long the group's pods may wait at
+	// Permit for their siblings before being rejected.
+	// +optional
+	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
+}
+
+// PodGroupStatus is the observed state of a PodGroup.
+type PodGroupStatus struct {
+	// Phase is the current phase of the PodGroup.
+	// +optional
+	Phase PodGroupPhase `json:"phase,omitempty"`
+
+	// Running is the number of pods of the group currently running.
+	// +optional
+	Running int32 `json:"running,omitempty"`
+
+	// Succeeded is the number of pods of the group that completed
+	// successfully.
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of pods of the group that failed.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroup is the Schema for the podgroups API. It is the source of truth
+// for gang membership: CustomScheduler.PreFilter resolves a pod's group
+// through it instead of relying solely on pod labels.
+type PodGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec PodGroupSpec `json:"spec,omitempty"`
+	// +optional
+	Status PodGroupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroupList is a list of PodGroups.
+type PodGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PodGroup `json:"items"`
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	schedv1alpha1 "github.com/kevin1010607/NTHU-Scheduler-Plugin/pkg/apis/scheduling/v1alpha1"
+)
+
+// podGroupLabel is the pod label that associates a pod with a PodGroup.
+const podGroupLabel = "scheduling.nthu.io/pod-group"
+
+// PodGroupController reconciles PodGroup status from the pods that belong
+// to it. It is the source of truth the CustomScheduler's PreFilter reads.
+type PodGroupController struct {
+	client        schedv1alpha1.SchedulingV1alpha1Interface
+	podGroupInformer cache.SharedIndexInformer
+	podGroupLister   *PodGroupLister
+	podInformer      cache.SharedIndexInformer
+	podLister        corelisters.PodLister
+	queue            workqueue.RateLimitingInterface
+}
+
+// NewPodGroupController wires up a controller watching podGroupInformer and
+// podInformer, reconciling against pods observed through podLister.
+func NewPodGroupController(client schedv1alpha1.SchedulingV1alpha1Interface, podGroupInformer cache.SharedIndexInformer, podGroupLister *PodGroupLister, podInformer cache.SharedIndexInformer, podLister corelisters.PodLister) *PodGroupController {
+	c := &PodGroupController{
+		client:           client,
+		podGroupInformer: podGroupInformer,
+		podGroupLister:   podGroupLister,
+		podInformer:      podInformer,
+		podLister:        podLister,
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "podgroup"),
+	}
+
+	podGroupInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueue(obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) { c.enqueue(newObj) },
+	})
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueuePod(obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) { c.enqueuePod(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueuePod(obj) },
+	})
+
+	return c
+}
+
+func (c *PodGroupController) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Printf("Error building PodGroup key: %v", err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueuePod translates a pod event into a reconcile of the PodGroup it
+// belongs to, so status updates don't only happen on the PodGroup's own
+// Add/Update events.
+func (c *PodGroupController) enqueuePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			pod, ok = tombstone.Obj.(*v1.Pod)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	group, ok := pod.Labels[podGroupLabel]
+	if !ok {
+		return
+	}
+	c.queue.Add(pod.Namespace + "/" + group)
+}
+
+// Run starts workers processing the queue until stopCh is closed.
+func (c *PodGroupController) Run(workers int, stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	if !cache.WaitForCacheSync(stopCh, c.podGroupInformer.HasSynced, c.podInformer.HasSynced) {
+		log.Printf("PodGroupController: caches never synced")
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *PodGroupController) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+func (c *PodGroupController) processNextItem() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.reconcile(key.(string)); err != nil {
+		log.Printf("Error reconciling PodGroup %s: %v", key, err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// reconcile recomputes a PodGroup's status from its member pods and pushes
+// it back to the API server.
+func (c *PodGroupController) reconcile(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	podGroup, err := c.podGroupLister.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{podGroupLabel: name})
+	pods, err := c.podLister.Pods(namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	status := computePodGroupStatus(pods, podGroup.Spec.MinMember)
+	if podGroup.Status == status {
+		return nil
+	}
+
+	updated := podGroup.DeepCopy()
+	updated.Status = status
+	_, err = c.client.PodGroups(namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// computePodGroupStatus derives a PodGroup's status from the current phase
+// of its member pods.
+func computePodGroupStatus(pods []*v1.Pod, minMember int32) schedv1alpha1.PodGroupStatus {
+	status := schedv1alpha1.PodGroupStatus{Phase: schedv1alpha1.PodGroupPending}
+	for _, pod := range pods {
+		switch pod.Status.Phase {
+		case v1.PodRunning:
+			status.Running++
+		case v1.PodSucceeded:
+			status.Succeeded++
+		case v1.PodFailed:
+			status.Failed++
+		}
+	}
+
+	switch {
+	case status.Succeeded >= minMember:
+		status.Phase = schedv1alpha1.PodGroupFinished
+	case status.Failed > 0 && status.Running+status.Succeeded < minMember:
+		status.Phase = schedv1alpha1.PodGroupFailed
+	case status.Running >= minMember:
+		status.Phase = schedv1alpha1.PodGroupRunning
+	case int32(len(pods)) >= minMember:
+		status.Phase = schedv1alpha1.PodGroupPreScheduling
+	}
+
+	return status
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedv1alpha1 "github.com/kevin1010607/NTHU-Scheduler-Plugin/pkg/apis/scheduling/v1alpha1"
+)
+
+func podWithPhase(phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{Status: v1.PodStatus{Phase: phase}}
+}
+
+func TestComputePodGroupStatus(t *testing.T) {
+	cases := []struct {
+		name      string
+		pods      []*v1.Pod
+		minMember int32
+		want      schedv1alpha1.PodGroupPhase
+	}{
+		{"no pods yet", nil, 2, schedv1alpha1.PodGroupPending},
+		{"not enough pods", []*v1.Pod{podWithPhase(v1.PodPending)}, 2, schedv1alpha1.PodGroupPending},
+		{"enough pods, none scheduled", []*v1.Pod{podWithPhase(v1.PodPending), podWithPhase(v1.PodPending)}, 2, schedv1alpha1.PodGroupPreScheduling},
+		{"minMember running", []*v1.Pod{podWithPhase(v1.PodRunning), podWithPhase(v1.PodRunning)}, 2, schedv1alpha1.PodGroupRunning},
+		{"minMember succeeded", []*v1.Pod{podWithPhase(v1.PodSucceeded), podWithPhase(v1.PodSucceeded)}, 2, schedv1alpha1.PodGroupFinished},
+		{"failed below minMember", []*v1.Pod{podWithPhase(v1.PodFailed), podWithPhase(v1.PodRunning)}, 2, schedv1alpha1.PodGroupFailed},
+		{"failed but still enough running", []*v1.Pod{podWithPhase(v1.PodFailed), podWithPhase(v1.PodRunning), podWithPhase(v1.PodRunning)}, 2, schedv1alpha1.PodGroupRunning},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status := computePodGroupStatus(c.pods, c.minMember)
+			if status.Phase != c.want {
+				t.Errorf("computePodGroupStatus() phase = %s, want %s", status.Phase, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	schedv1alpha1 "github.com/kevin1010607/NTHU-Scheduler-Plugin/pkg/apis/scheduling/v1alpha1"
+)
+
+// PodGroupLister lists PodGroups from the informer's local store.
+type PodGroupLister struct {
+	indexer cache.Indexer
+}
+
+// Get returns the PodGroup named name in namespace, or an error if it is
+// not present in the informer's store.
+func (l *PodGroupLister) Get(namespace, name string) (*schedv1alpha1.PodGroup, error) {
+	obj, exists, err := l.indexer.GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(schedv1alpha1.Resource("podgroups"), name)
+	}
+	return obj.(*schedv1alpha1.PodGroup), nil
+}
+
+// List returns every PodGroup currently in the informer's store.
+func (l *PodGroupLister) List() ([]*schedv1alpha1.PodGroup, error) {
+	groups := make([]*schedv1alpha1.PodGroup, 0, len(l.indexer.List()))
+	for _, obj := range l.indexer.List() {
+		groups = append(groups, obj.(*schedv1alpha1.PodGroup))
+	}
+	return groups, nil
+}
+
+// NewPodGroupInformer builds a shared index informer that keeps a local
+// cache of PodGroups in sync with the API server, along with a lister on
+// top of that cache.
+func NewPodGroupInformer(client schedv1alpha1.SchedulingV1alpha1Interface, namespace string, resyncPeriod time.Duration) (cache.SharedIndexInformer, *PodGroupLister) {
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.PodGroups(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.PodGroups(namespace).Watch(context.Background(), options)
+			},
+		},
+		&schedv1alpha1.PodGroup{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+
+	return informer, &PodGroupLister{indexer: informer.GetIndexer()}
+}
@@ -0,0 +1,191 @@
+package plugins
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const defaultExtenderTimeout = 5 * time.Second
+
+// ExtenderTLSConfig configures HTTPS access to an extender.
+type ExtenderTLSConfig struct {
+	Insecure   bool   `json:"insecure,omitempty"`
+	ServerName string `json:"serverName,omitempty"`
+	CertFile   string `json:"certFile,omitempty"`
+	KeyFile    string `json:"keyFile,omitempty"`
+	CAFile     string `json:"caFile,omitempty"`
+}
+
+// ExtenderConfig describes an out-of-process HTTP(S) scheduler extender.
+type ExtenderConfig struct {
+	URLPrefix      string             `json:"urlPrefix"`
+	FilterVerb     string             `json:"filterVerb,omitempty"`
+	PrioritizeVerb string             `json:"prioritizeVerb,omitempty"`
+	Weight         int64              `json:"weight,omitempty"`
+	TimeoutSeconds int64              `json:"timeoutSeconds,omitempty"`
+	Ignorable      bool               `json:"ignorable,omitempty"`
+	TLSConfig      *ExtenderTLSConfig `json:"tlsConfig,omitempty"`
+}
+
+// Extender calls an out-of-process HTTP(S) scheduler extender to filter and
+// prioritize nodes, the same protocol kube-scheduler itself speaks.
+type Extender struct {
+	urlPrefix      string
+	filterVerb     string
+	prioritizeVerb string
+	weight         int64
+	ignorable      bool
+	client         *http.Client
+}
+
+// NewExtender builds an Extender from cfg.
+func NewExtender(cfg *ExtenderConfig) (*Extender, error) {
+	if cfg.URLPrefix == "" {
+		return nil, fmt.Errorf("urlPrefix must not be empty")
+	}
+
+	timeout := defaultExtenderTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+
+	transport := http.DefaultTransport
+	if cfg.TLSConfig != nil {
+		tlsConfig, err := buildExtenderTLSConfig(cfg.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	weight := cfg.Weight
+	if weight == 0 {
+		weight = 1
+	}
+
+	return &Extender{
+		urlPrefix:      strings.TrimSuffix(cfg.URLPrefix, "/"),
+		filterVerb:     cfg.FilterVerb,
+		prioritizeVerb: cfg.PrioritizeVerb,
+		weight:         weight,
+		ignorable:      cfg.Ignorable,
+		client:         &http.Client{Timeout: timeout, Transport: transport},
+	}, nil
+}
+
+func buildExtenderTLSConfig(cfg *ExtenderTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.Insecure, ServerName: cfg.ServerName}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading extender client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading extender CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(ca)
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// Filter asks the extender whether pod can go on nodeName. A FilterVerb of
+// "" means the extender only scores, and Filter always succeeds.
+func (e *Extender) Filter(pod *v1.Pod, nodeName string) *framework.Status {
+	if e.filterVerb == "" {
+		return framework.NewStatus(framework.Success, "")
+	}
+
+	nodeNames := []string{nodeName}
+	args := extenderv1.ExtenderArgs{Pod: pod, NodeNames: &nodeNames}
+
+	var result extenderv1.ExtenderFilterResult
+	if err := e.post(e.filterVerb, args, &result); err != nil {
+		if e.ignorable {
+			return framework.NewStatus(framework.Success, "")
+		}
+		return framework.NewStatus(framework.Error, fmt.Sprintf("Error calling extender filter: %v", err))
+	}
+
+	if result.Error != "" {
+		return framework.NewStatus(framework.Unschedulable, result.Error)
+	}
+	if reason, failed := result.FailedNodes[nodeName]; failed {
+		return framework.NewStatus(framework.Unschedulable, reason)
+	}
+
+	return framework.NewStatus(framework.Success, "")
+}
+
+// Prioritize asks the extender to score nodeName and returns weight*score.
+// Timeouts and transport errors yield score 0 with framework.Success when
+// the extender is ignorable, and framework.Error otherwise.
+func (e *Extender) Prioritize(pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	if e.prioritizeVerb == "" {
+		return 0, framework.NewStatus(framework.Success, "")
+	}
+
+	nodeNames := []string{nodeName}
+	args := extenderv1.ExtenderArgs{Pod: pod, NodeNames: &nodeNames}
+
+	var result extenderv1.HostPriorityList
+	if err := e.post(e.prioritizeVerb, args, &result); err != nil {
+		if e.ignorable {
+			return 0, framework.NewStatus(framework.Success, "")
+		}
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("Error calling extender prioritize: %v", err))
+	}
+
+	for _, hostPriority := range result {
+		if hostPriority.Host == nodeName {
+			return hostPriority.Score * e.weight, framework.NewStatus(framework.Success, "")
+		}
+	}
+
+	return 0, framework.NewStatus(framework.Success, "")
+}
+
+// post sends body as JSON to e.urlPrefix/verb and decodes the response into out.
+func (e *Extender) post(verb string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshaling extender request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s", e.urlPrefix, verb), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building extender request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling extender: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("extender returned status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,92 @@
+package plugins
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestExtenderFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(extenderv1.ExtenderFilterResult{
+			FailedNodes: map[string]string{"bad-node": "not enough resources"},
+		})
+	}))
+	defer server.Close()
+
+	e, err := NewExtender(&ExtenderConfig{URLPrefix: server.URL, FilterVerb: "filter"})
+	if err != nil {
+		t.Fatalf("NewExtender() error = %v", err)
+	}
+
+	if status := e.Filter(&v1.Pod{}, "good-node"); !status.IsSuccess() {
+		t.Errorf("Filter(good-node) = %v, want success", status)
+	}
+	if status := e.Filter(&v1.Pod{}, "bad-node"); status.IsSuccess() {
+		t.Errorf("Filter(bad-node) = %v, want failure", status)
+	}
+}
+
+func TestExtenderFilterIgnorableOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	e, err := NewExtender(&ExtenderConfig{URLPrefix: server.URL, FilterVerb: "filter", Ignorable: true})
+	if err != nil {
+		t.Fatalf("NewExtender() error = %v", err)
+	}
+
+	if status := e.Filter(&v1.Pod{}, "any-node"); !status.IsSuccess() {
+		t.Errorf("Filter() on a failing ignorable extender = %v, want success", status)
+	}
+}
+
+func TestExtenderFilterErrorWhenNotIgnorable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	e, err := NewExtender(&ExtenderConfig{URLPrefix: server.URL, FilterVerb: "filter"})
+	if err != nil {
+		t.Fatalf("NewExtender() error = %v", err)
+	}
+
+	if status := e.Filter(&v1.Pod{}, "any-node"); status.Code() != framework.Error {
+		t.Errorf("Filter() on a failing non-ignorable extender = %v, want Error", status)
+	}
+}
+
+func TestExtenderPrioritize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(extenderv1.HostPriorityList{
+			{Host: "node-1", Score: 5},
+		})
+	}))
+	defer server.Close()
+
+	e, err := NewExtender(&ExtenderConfig{URLPrefix: server.URL, PrioritizeVerb: "prioritize", Weight: 2})
+	if err != nil {
+		t.Fatalf("NewExtender() error = %v", err)
+	}
+
+	score, status := e.Prioritize(&v1.Pod{}, "node-1")
+	if !status.IsSuccess() {
+		t.Fatalf("Prioritize() status = %v, want success", status)
+	}
+	if want := int64(10); score != want {
+		t.Errorf("Prioritize() score = %d, want %d", score, want)
+	}
+
+	score, status = e.Prioritize(&v1.Pod{}, "node-2")
+	if !status.IsSuccess() || score != 0 {
+		t.Errorf("Prioritize() for an unscored node = (%d, %v), want (0, success)", score, status)
+	}
+}
@@ -0,0 +1,91 @@
+package plugins
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodGroupInfo tracks the Permit progress of a single pod group across one
+// scheduling cycle.
+type PodGroupInfo struct {
+	// minAvailable is the number of group members that must reach Permit
+	// before any of them are released.
+	minAvailable int
+	// arrivalTime is when the first pod of the group reached Permit; it is
+	// used to enforce scheduleCycleValidDuration and to Sweep groups whose
+	// siblings never showed up.
+	arrivalTime time.Time
+}
+
+// PodGroupManager tracks the arrival time of every pod group currently going
+// through the Permit phase. It does not track which pods are waiting: a pod
+// can leave Permit on its own, without the plugin observing it, once the
+// framework's own permitWaitingTime elapses, so the live count of waiting
+// siblings is instead read from handle.IterateOverWaitingPods.
+type PodGroupManager struct {
+	mu     sync.Mutex
+	groups map[string]*PodGroupInfo
+}
+
+// NewPodGroupManager creates an empty PodGroupManager.
+func NewPodGroupManager() *PodGroupManager {
+	return &PodGroupManager{
+		groups: make(map[string]*PodGroupInfo),
+	}
+}
+
+// podGroupKey returns the key a pod's group is tracked under: namespace/name,
+// preferring the PodGroup CRD label and falling back to the deprecated
+// groupNameLabel.
+func podGroupKey(pod *v1.Pod) string {
+	if name, ok := pod.Labels[podGroupLabel]; ok {
+		return pod.Namespace + "/" + name
+	}
+	return pod.Namespace + "/" + pod.Labels[groupNameLabel]
+}
+
+// Arrive records that pod has reached Permit for its group, if it is the
+// first of the group to do so, and returns when the group first arrived.
+func (m *PodGroupManager) Arrive(pod *v1.Pod, minAvailable int) (arrivalTime time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := podGroupKey(pod)
+	info, ok := m.groups[key]
+	if !ok {
+		info = &PodGroupInfo{
+			minAvailable: minAvailable,
+			arrivalTime:  time.Now(),
+		}
+		m.groups[key] = info
+	}
+
+	return info.arrivalTime
+}
+
+// Delete forgets the group tracked under key, e.g. once it has been
+// released or rejected.
+func (m *PodGroupManager) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.groups, key)
+}
+
+// Sweep deletes every group whose arrivalTime is older than maxAge. Without
+// this, a group whose siblings never came back to Permit again (e.g. because
+// they were deleted, or preempted elsewhere) would stay in groups forever:
+// nothing else revisits a group once its last pod stopped calling Permit.
+func (m *PodGroupManager) Sweep(maxAge time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := time.Now().Add(-maxAge)
+	for key, info := range m.groups {
+		if info.arrivalTime.Before(cutoff) {
+			delete(m.groups, key)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package plugins
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func podWithGroup(name, group string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels:    map[string]string{podGroupLabel: group},
+		},
+	}
+}
+
+func TestPodGroupManagerArriveReturnsSameArrivalTime(t *testing.T) {
+	m := NewPodGroupManager()
+	pod1 := podWithGroup("pod-1", "group-a")
+	pod2 := podWithGroup("pod-2", "group-a")
+
+	first := m.Arrive(pod1, 2)
+	second := m.Arrive(pod2, 2)
+
+	if !first.Equal(second) {
+		t.Errorf("expected every pod in a group to observe the same arrival time, got %v and %v", first, second)
+	}
+}
+
+func TestPodGroupManagerArriveIsPerGroup(t *testing.T) {
+	m := NewPodGroupManager()
+	podA := podWithGroup("pod-a", "group-a")
+	podB := podWithGroup("pod-b", "group-b")
+
+	m.Arrive(podA, 1)
+	time.Sleep(time.Millisecond)
+	arrivalB := m.Arrive(podB, 1)
+
+	if _, ok := m.groups["default/group-a"]; !ok {
+		t.Fatalf("expected group-a to be tracked")
+	}
+	if m.groups["default/group-b"].arrivalTime.Before(arrivalB.Add(-time.Second)) {
+		t.Errorf("group-b arrival time looks wrong: %v", arrivalB)
+	}
+}
+
+func TestPodGroupManagerDelete(t *testing.T) {
+	m := NewPodGroupManager()
+	pod := podWithGroup("pod-1", "group-a")
+	m.Arrive(pod, 1)
+
+	m.Delete(podGroupKey(pod))
+
+	if _, ok := m.groups["default/group-a"]; ok {
+		t.Errorf("expected group-a to be forgotten after Delete")
+	}
+}
+
+func TestPodGroupManagerSweep(t *testing.T) {
+	m := NewPodGroupManager()
+	stale := podWithGroup("pod-1", "stale-group")
+	fresh := podWithGroup("pod-2", "fresh-group")
+
+	m.Arrive(stale, 1)
+	m.groups["default/stale-group"].arrivalTime = time.Now().Add(-time.Hour)
+	m.Arrive(fresh, 1)
+
+	m.Sweep(time.Minute)
+
+	if _, ok := m.groups["default/stale-group"]; ok {
+		t.Errorf("expected stale-group to be swept")
+	}
+	if _, ok := m.groups["default/fresh-group"]; !ok {
+		t.Errorf("expected fresh-group to survive the sweep")
+	}
+}
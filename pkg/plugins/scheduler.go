@@ -7,23 +7,46 @@ import (
 	"log"
 	"math"
 	"strconv"
+	"time"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedv1alpha1 "github.com/kevin1010607/NTHU-Scheduler-Plugin/pkg/apis/scheduling/v1alpha1"
+	"github.com/kevin1010607/NTHU-Scheduler-Plugin/pkg/controller"
 )
 
 type CustomSchedulerArgs struct {
-	Mode string `json:"mode"`
+	Mode                      string                  `json:"mode"`
+	PermitWaitingTimeSeconds  int64                   `json:"permitWaitingTime,omitempty"`
+	ScheduleCycleValidSeconds int64                   `json:"scheduleCycleValidDuration,omitempty"`
+	Shape                     []UtilizationShapePoint `json:"shape,omitempty"`
+	Resources                 []ResourceSpec          `json:"resources,omitempty"`
+	Extender                  *ExtenderConfig         `json:"extender,omitempty"`
 }
 
 type CustomScheduler struct {
-	handle 	framework.Handle
-	scoreMode string
+	handle                     framework.Handle
+	scoreMode                  string
+	podGroupManager            *PodGroupManager
+	permitWaitingTime          time.Duration
+	scheduleCycleValidDuration time.Duration
+	podGroupLister             *controller.PodGroupLister
+	shape                      []UtilizationShapePoint
+	resources                  []ResourceSpec
+	extender                   *Extender
 }
 
 var _ framework.PreFilterPlugin = &CustomScheduler{}
+var _ framework.FilterPlugin = &CustomScheduler{}
 var _ framework.ScorePlugin = &CustomScheduler{}
+var _ framework.PermitPlugin = &CustomScheduler{}
+var _ framework.PostBindPlugin = &CustomScheduler{}
 
 // Name is the name of the plugin used in Registry and configurations.
 const (
@@ -31,7 +54,16 @@ const (
 	groupNameLabel 		string = "podGroup"
 	minAvailableLabel 	string = "minAvailable"
 	leastMode			string = "Least"
-	mostMode			string = "Most"			
+	mostMode			string = "Most"
+	requestedToCapacityRatioMode string = "RequestedToCapacityRatio"
+
+	// podGroupLabel is the label that associates a pod with a PodGroup CRD
+	// object. It supersedes groupNameLabel/minAvailableLabel, which are now
+	// kept only as a deprecated fallback for clusters without the CRD.
+	podGroupLabel string = "scheduling.nthu.io/pod-group"
+
+	defaultPermitWaitingTime          = 10 * time.Second
+	defaultScheduleCycleValidDuration = 60 * time.Second
 )
 
 func (cs *CustomScheduler) Name() string {
@@ -42,6 +74,8 @@ func (cs *CustomScheduler) Name() string {
 func New(obj runtime.Object, h framework.Handle) (framework.Plugin, error) {
 	cs := CustomScheduler{}
 	mode := leastMode
+	cs.permitWaitingTime = defaultPermitWaitingTime
+	cs.scheduleCycleValidDuration = defaultScheduleCycleValidDuration
 	if obj != nil {
 		args := obj.(*runtime.Unknown)
 		var csArgs CustomSchedulerArgs
@@ -49,23 +83,131 @@ func New(obj runtime.Object, h framework.Handle) (framework.Plugin, error) {
 			fmt.Printf("Error unmarshal: %v\n", err)
 		}
 		mode = csArgs.Mode
-		if mode != leastMode && mode != mostMode {
+		if mode != leastMode && mode != mostMode && mode != requestedToCapacityRatioMode {
 			return nil, fmt.Errorf("invalid mode, got %s", mode)
 		}
+		if mode == requestedToCapacityRatioMode {
+			if err := validateShape(csArgs.Shape); err != nil {
+				return nil, fmt.Errorf("invalid shape: %w", err)
+			}
+			if len(csArgs.Resources) == 0 {
+				return nil, fmt.Errorf("%s mode requires a non-empty resources list", requestedToCapacityRatioMode)
+			}
+		}
+		if len(csArgs.Resources) > 0 {
+			if err := validateResources(csArgs.Resources); err != nil {
+				return nil, fmt.Errorf("invalid resources: %w", err)
+			}
+			cs.resources = csArgs.Resources
+		}
+		cs.shape = csArgs.Shape
+		if csArgs.PermitWaitingTimeSeconds > 0 {
+			cs.permitWaitingTime = time.Duration(csArgs.PermitWaitingTimeSeconds) * time.Second
+		}
+		if csArgs.ScheduleCycleValidSeconds > 0 {
+			cs.scheduleCycleValidDuration = time.Duration(csArgs.ScheduleCycleValidSeconds) * time.Second
+		}
+		if csArgs.Extender != nil {
+			extender, err := NewExtender(csArgs.Extender)
+			if err != nil {
+				return nil, fmt.Errorf("invalid extender config: %w", err)
+			}
+			cs.extender = extender
+		}
 	}
 	cs.handle = h
 	cs.scoreMode = mode
+	cs.podGroupManager = NewPodGroupManager()
+	go wait.Until(func() { cs.podGroupManager.Sweep(cs.scheduleCycleValidDuration) }, cs.scheduleCycleValidDuration, wait.NeverStop)
+
+	config, err := podGroupClientConfig()
+	if err != nil {
+		// The PodGroup CRD is optional: clusters that only use the
+		// groupNameLabel/minAvailableLabel protocol, and local/CI runs with
+		// no kubeconfig at all, should still be able to start the plugin.
+		log.Printf("PodGroup CRD disabled, no kubeconfig available: %v.", err)
+	} else {
+		pgClient, err := schedv1alpha1.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("building PodGroup client: %w", err)
+		}
+
+		pgInformer, pgLister := controller.NewPodGroupInformer(pgClient, metav1.NamespaceAll, 0)
+		cs.podGroupLister = pgLister
+
+		podInformer := h.SharedInformerFactory().Core().V1().Pods()
+		pgController := controller.NewPodGroupController(pgClient, pgInformer, pgLister, podInformer.Informer(), podInformer.Lister())
+		go pgInformer.Run(wait.NeverStop)
+		go pgController.Run(1, wait.NeverStop)
+	}
+
 	log.Printf("Custom scheduler runs with the mode: %s.", mode)
 
 	return &cs, nil
 }
 
-// filter the pod if the pod in group is less than minAvailable
+// podGroupClientConfig resolves a rest.Config for the PodGroup client,
+// preferring the in-cluster config and falling back to the kubeconfig the
+// rest of client-go's tooling conventionally looks for. Clusters without
+// either are expected to run without the CRD, so callers treat an error here
+// as "CRD disabled" rather than a fatal plugin-startup error.
+func podGroupClientConfig() (*rest.Config, error) {
+	if config, err := rest.InClusterConfig(); err == nil {
+		return config, nil
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+}
+
+// PreFilter resolves the pod's gang, either through its PodGroup CRD or,
+// when the pod doesn't carry a podGroupLabel, through the deprecated
+// groupNameLabel/minAvailableLabel pair, and rejects it until enough
+// siblings exist to admit the whole gang.
 func (cs *CustomScheduler) PreFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod) (*framework.PreFilterResult, *framework.Status) {
 	log.Printf("Pod %s is in Prefilter phase.", pod.Name)
 
-	// TODO
-	// 1. extract the label of the pod
+	if groupName, ok := pod.Labels[podGroupLabel]; ok {
+		return cs.preFilterPodGroup(state, pod, groupName)
+	}
+
+	return cs.preFilterLegacyLabels(state, pod)
+}
+
+// preFilterPodGroup implements PreFilter against the PodGroup CRD.
+func (cs *CustomScheduler) preFilterPodGroup(state *framework.CycleState, pod *v1.Pod, groupName string) (*framework.PreFilterResult, *framework.Status) {
+	if cs.podGroupLister == nil {
+		return nil, framework.NewStatus(framework.Error, "PodGroup CRD is not available on this scheduler instance.")
+	}
+
+	podGroup, err := cs.podGroupLister.Get(pod.Namespace, groupName)
+	if err != nil {
+		return nil, framework.NewStatus(framework.Error, fmt.Sprintf("Error getting PodGroup %s/%s: %v.", pod.Namespace, groupName, err))
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{podGroupLabel: groupName})
+	pods, err := cs.handle.SharedInformerFactory().Core().V1().Pods().Lister().Pods(pod.Namespace).List(selector)
+	if err != nil {
+		return nil, framework.NewStatus(framework.Error, fmt.Sprintf("Error listing pods.: %v.", err))
+	}
+
+	if int32(len(pods)) < podGroup.Spec.MinMember {
+		return nil, framework.NewStatus(framework.Unschedulable, fmt.Sprintf("PodGroup %s has %d pods, needs %d.", groupName, len(pods), podGroup.Spec.MinMember))
+	}
+
+	if status := cs.checkMinResources(podGroup); !status.IsSuccess() {
+		return nil, status
+	}
+
+	cs.activateSiblings(pod, state, pods)
+
+	return nil, framework.NewStatus(framework.Success, "")
+}
+
+// preFilterLegacyLabels is the deprecated label-only gang protocol, kept for
+// clusters that have not migrated to the PodGroup CRD yet.
+func (cs *CustomScheduler) preFilterLegacyLabels(state *framework.CycleState, pod *v1.Pod) (*framework.PreFilterResult, *framework.Status) {
 	group, groupExists := pod.Labels[groupNameLabel]
 	minAvailableStr, minAvailableExists := pod.Labels[minAvailableLabel]
 	if !groupExists || !minAvailableExists {
@@ -76,21 +218,80 @@ func (cs *CustomScheduler) PreFilter(ctx context.Context, state *framework.Cycle
 		return nil, framework.NewStatus(framework.Error, "Invalid minAvailable value.")
 	}
 
-	// 2. retrieve the pod with the same group label
 	selector := labels.SelectorFromSet(labels.Set{groupNameLabel: group})
 	pods, err := cs.handle.SharedInformerFactory().Core().V1().Pods().Lister().List(selector)
 	if err != nil {
 		return nil, framework.NewStatus(framework.Error, fmt.Sprintf("Error listing pods.: %v.", err))
 	}
 
-	// 3. justify if the pod can be scheduled
 	if len(pods) < minAvailable {
 		return nil, framework.NewStatus(framework.Unschedulable, "Not enough pods in the group.")
 	}
 
+	cs.activateSiblings(pod, state, pods)
+
 	return nil, framework.NewStatus(framework.Success, "")
 }
 
+// checkMinResources rejects the pod's gang if the cluster's current free
+// capacity falls below podGroup.Spec.MinResources. MinResources is a
+// generic v1.ResourceList, so every requested name (built-in or extended,
+// e.g. nvidia.com/gpu) is read the same way resourceValue already reads
+// resources for the weighted scorers.
+func (cs *CustomScheduler) checkMinResources(podGroup *schedv1alpha1.PodGroup) *framework.Status {
+	if len(podGroup.Spec.MinResources) == 0 {
+		return framework.NewStatus(framework.Success, "")
+	}
+
+	nodeInfos, err := cs.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return framework.NewStatus(framework.Error, fmt.Sprintf("Error listing node infos: %v.", err))
+	}
+
+	for name, want := range podGroup.Spec.MinResources {
+		var free int64
+		for _, nodeInfo := range nodeInfos {
+			free += resourceValue(nodeInfo.Allocatable, name) - resourceValue(nodeInfo.Requested, name)
+		}
+
+		wantValue := want.Value()
+		if name == v1.ResourceCPU {
+			wantValue = want.MilliValue()
+		}
+		if free < wantValue {
+			return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Cluster free %s is below PodGroup minResources.", name))
+		}
+	}
+
+	return framework.NewStatus(framework.Success, "")
+}
+
+// activateSiblings stashes a pod's siblings into the cycle state so the
+// framework moves them from the unschedulable queue back into activeQ once
+// the current pod finishes this scheduling cycle.
+func (cs *CustomScheduler) activateSiblings(pod *v1.Pod, state *framework.CycleState, siblings []*v1.Pod) {
+	if len(siblings) == 0 {
+		return
+	}
+
+	c, err := state.Read(framework.PodsToActivateKey)
+	if err != nil {
+		c = framework.NewPodsToActivate()
+		state.Write(framework.PodsToActivateKey, c)
+	}
+
+	if podsToActivate, ok := c.(*framework.PodsToActivate); ok {
+		podsToActivate.Lock()
+		for _, sibling := range siblings {
+			if sibling.UID == pod.UID {
+				continue
+			}
+			podsToActivate.Map[fmt.Sprintf("%s/%s", sibling.Namespace, sibling.Name)] = sibling
+		}
+		podsToActivate.Unlock()
+	}
+}
+
 
 // PreFilterExtensions returns a PreFilterExtensions interface if the plugin implements one.
 func (cs *CustomScheduler) PreFilterExtensions() framework.PreFilterExtensions {
@@ -98,19 +299,172 @@ func (cs *CustomScheduler) PreFilterExtensions() framework.PreFilterExtensions {
 }
 
 
+// Filter delegates to the configured HTTP extender, if any, so clusters can
+// plug in filtering logic without patching kube-scheduler itself.
+func (cs *CustomScheduler) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if cs.extender == nil {
+		return framework.NewStatus(framework.Success, "")
+	}
+	return cs.extender.Filter(pod, nodeInfo.Node().Name)
+}
+
+
+// groupAndMinAvailable resolves a pod's group name, the minimum number of
+// members it must reach before being admitted, and how long the group's
+// schedule cycle stays valid, preferring the PodGroup CRD and falling back
+// to the deprecated groupNameLabel/minAvailableLabel pair. A zero duration
+// means the caller should fall back to cs.scheduleCycleValidDuration. An
+// empty group with a successful status means the pod is not gang-scheduled.
+func (cs *CustomScheduler) groupAndMinAvailable(pod *v1.Pod) (string, int, time.Duration, *framework.Status) {
+	if group, ok := pod.Labels[podGroupLabel]; ok {
+		if cs.podGroupLister == nil {
+			return "", 0, 0, framework.NewStatus(framework.Error, "PodGroup CRD is not available on this scheduler instance.")
+		}
+		podGroup, err := cs.podGroupLister.Get(pod.Namespace, group)
+		if err != nil {
+			return "", 0, 0, framework.NewStatus(framework.Error, fmt.Sprintf("Error getting PodGroup %s/%s: %v.", pod.Namespace, group, err))
+		}
+		var scheduleTimeout time.Duration
+		if podGroup.Spec.ScheduleTimeoutSeconds != nil {
+			scheduleTimeout = time.Duration(*podGroup.Spec.ScheduleTimeoutSeconds) * time.Second
+		}
+		return group, int(podGroup.Spec.MinMember), scheduleTimeout, framework.NewStatus(framework.Success, "")
+	}
+
+	group, groupExists := pod.Labels[groupNameLabel]
+	minAvailableStr, minAvailableExists := pod.Labels[minAvailableLabel]
+	if !groupExists || !minAvailableExists {
+		return "", 0, 0, framework.NewStatus(framework.Success, "")
+	}
+	minAvailable, err := strconv.Atoi(minAvailableStr)
+	if err != nil {
+		return "", 0, 0, framework.NewStatus(framework.Error, "Invalid minAvailable value.")
+	}
+	return group, minAvailable, 0, framework.NewStatus(framework.Success, "")
+}
+
+// Permit holds a pod that belongs to a group until minAvailable pods of the
+// group, counting the pod itself, have reached Permit, then releases all of
+// them together.
+func (cs *CustomScheduler) Permit(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (*framework.Status, time.Duration) {
+	group, minAvailable, scheduleTimeout, status := cs.groupAndMinAvailable(pod)
+	if !status.IsSuccess() {
+		return status, 0
+	}
+	if group == "" {
+		// pods outside of any group are not gang-scheduled
+		return framework.NewStatus(framework.Success, ""), 0
+	}
+	if scheduleTimeout <= 0 {
+		scheduleTimeout = cs.scheduleCycleValidDuration
+	}
+
+	key := podGroupKey(pod)
+	arrivalTime := cs.podGroupManager.Arrive(pod, minAvailable)
+	// pod itself hasn't been marked as waiting by the framework yet, so add
+	// it to the count of siblings already parked in Permit.
+	waiting := cs.countWaitingSiblings(key) + 1
+	log.Printf("Pod %s is in Permit phase. %d/%d pods of group %s are waiting.", pod.Name, waiting, minAvailable, group)
+
+	if waiting >= minAvailable {
+		cs.releaseWaitingSiblings(key)
+		return framework.NewStatus(framework.Success, ""), 0
+	}
+
+	if scheduleTimeout > 0 && time.Since(arrivalTime) > scheduleTimeout {
+		cs.rejectWaitingSiblings(key, fmt.Sprintf("Schedule cycle for group %s expired.", group))
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Schedule cycle for group %s expired.", group)), 0
+	}
+
+	return framework.NewStatus(framework.Wait, fmt.Sprintf("Waiting for %d/%d pods of group %s.", waiting, minAvailable, group)), cs.permitWaitingTime
+}
+
+// countWaitingSiblings returns how many pods the framework currently has
+// parked in Permit for the group identified by key. This is always read live
+// rather than tracked locally, since a pod can leave Permit on its own, once
+// its own permitWaitingTime elapses, without the plugin ever being notified.
+func (cs *CustomScheduler) countWaitingSiblings(key string) int {
+	waiting := 0
+	cs.handle.IterateOverWaitingPods(func(wp framework.WaitingPod) {
+		if podGroupKey(wp.GetPod()) == key {
+			waiting++
+		}
+	})
+	return waiting
+}
+
+// releaseWaitingSiblings allows every pod currently parked in Permit for the
+// group identified by key and forgets the group's bookkeeping.
+func (cs *CustomScheduler) releaseWaitingSiblings(key string) {
+	cs.handle.IterateOverWaitingPods(func(wp framework.WaitingPod) {
+		if podGroupKey(wp.GetPod()) == key {
+			wp.Allow(Name)
+		}
+	})
+	cs.podGroupManager.Delete(key)
+}
+
+// rejectWaitingSiblings rejects every pod currently parked in Permit for the
+// group identified by key once its schedule cycle has expired.
+func (cs *CustomScheduler) rejectWaitingSiblings(key, reason string) {
+	cs.handle.IterateOverWaitingPods(func(wp framework.WaitingPod) {
+		if podGroupKey(wp.GetPod()) == key {
+			wp.Reject(Name, reason)
+		}
+	})
+	cs.podGroupManager.Delete(key)
+}
+
+
+// PostBind is called after a pod is successfully bound, used here only to
+// record that the pod completed its scheduling cycle.
+func (cs *CustomScheduler) PostBind(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) {
+	log.Printf("Pod %s is in PostBind phase, bound to node %s.", pod.Name, nodeName)
+}
+
+
 // Score invoked at the score extension point.
 func (cs *CustomScheduler) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
 	log.Printf("Pod %s is in Score phase. Calculate the score of Node %s.", pod.Name, nodeName)
 
+	score, status := cs.inProcessScore(pod, nodeName)
+	if !status.IsSuccess() {
+		return 0, status
+	}
+
+	if cs.extender != nil {
+		extenderScore, status := cs.extender.Prioritize(pod, nodeName)
+		if !status.IsSuccess() {
+			return 0, status
+		}
+		score += extenderScore
+	}
+
+	return score, framework.NewStatus(framework.Success, "")
+}
+
+// inProcessScore computes the score this plugin derives on its own, before
+// any configured extender is combined in.
+func (cs *CustomScheduler) inProcessScore(pod *v1.Pod, nodeName string) (int64, *framework.Status) {
 	// TODO
 	// 1. retrieve the node allocatable memory
 	nodeInfo, err := cs.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
 	if err != nil {
 		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("Error getting node info: %v", err))
 	}
-	memory := nodeInfo.Allocatable.Memory
 
-	// 2. return the score based on the scheduler mode
+	if cs.scoreMode == requestedToCapacityRatioMode {
+		return cs.scoreRequestedToCapacityRatio(pod, nodeInfo)
+	}
+
+	// 2. return the score based on the scheduler mode, aggregating the
+	// configured resources when present and falling back to memory-only
+	// otherwise
+	if len(cs.resources) > 0 {
+		return cs.scoreWeightedResources(pod, nodeInfo), framework.NewStatus(framework.Success, "")
+	}
+
+	memory := nodeInfo.Allocatable.Memory
 	score := int64(0)
 	if cs.scoreMode == leastMode {
 		score = -memory
@@ -0,0 +1,147 @@
+package plugins
+
+import (
+	"fmt"
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// builtinResourceNames are the resource names CustomScheduler understands
+// without needing to validate them as qualified extended resource names.
+var builtinResourceNames = map[string]bool{
+	string(v1.ResourceCPU):              true,
+	string(v1.ResourceMemory):           true,
+	string(v1.ResourceEphemeralStorage): true,
+}
+
+// UtilizationShapePoint is one point of the piecewise-linear function mapping
+// a resource's utilization percentage to a raw score.
+type UtilizationShapePoint struct {
+	Utilization int64 `json:"utilization"`
+	Score       int64 `json:"score"`
+}
+
+// ResourceSpec names a resource to score and how heavily it counts towards
+// the weighted average.
+type ResourceSpec struct {
+	Name   string `json:"name"`
+	Weight int64  `json:"weight"`
+}
+
+// validateShape checks that shape is not empty, is sorted by strictly
+// increasing utilization, and that every score falls within [0, 10].
+func validateShape(shape []UtilizationShapePoint) error {
+	if len(shape) == 0 {
+		return fmt.Errorf("shape must not be empty")
+	}
+	for i, point := range shape {
+		if point.Score < 0 || point.Score > 10 {
+			return fmt.Errorf("shape point %d has score %d outside [0, 10]", i, point.Score)
+		}
+		if i > 0 && shape[i-1].Utilization >= point.Utilization {
+			return fmt.Errorf("shape must be sorted by strictly increasing utilization, point %d (%d) does not follow point %d (%d)", i, point.Utilization, i-1, shape[i-1].Utilization)
+		}
+	}
+	return nil
+}
+
+// validateResources checks that every resource carries a positive weight.
+func validateResources(resources []ResourceSpec) error {
+	if len(resources) == 0 {
+		return fmt.Errorf("resources must not be empty")
+	}
+	for _, res := range resources {
+		if res.Weight <= 0 {
+			return fmt.Errorf("resource %s has non-positive weight %d", res.Name, res.Weight)
+		}
+		if builtinResourceNames[res.Name] {
+			continue
+		}
+		if errs := validation.IsQualifiedName(res.Name); len(errs) > 0 {
+			return fmt.Errorf("unknown resource name %q: %s", res.Name, errs[0])
+		}
+	}
+	return nil
+}
+
+// interpolateShape maps utilization (a percentage) through shape, linearly
+// interpolating between the two points that straddle it and clamping to the
+// endpoint scores outside shape's domain. shape must already be validated.
+func interpolateShape(shape []UtilizationShapePoint, utilization int64) int64 {
+	if utilization <= shape[0].Utilization {
+		return shape[0].Score
+	}
+	last := len(shape) - 1
+	if utilization >= shape[last].Utilization {
+		return shape[last].Score
+	}
+
+	for i := 1; i <= last; i++ {
+		next := shape[i]
+		if utilization > next.Utilization {
+			continue
+		}
+		prev := shape[i-1]
+		ratio := float64(utilization-prev.Utilization) / float64(next.Utilization-prev.Utilization)
+		return prev.Score + int64(math.Round(ratio*float64(next.Score-prev.Score)))
+	}
+
+	return shape[last].Score
+}
+
+// resourceValue reads name out of a framework.Resource, falling back to its
+// ScalarResources map for extended resources.
+func resourceValue(r *framework.Resource, name v1.ResourceName) int64 {
+	switch name {
+	case v1.ResourceCPU:
+		return r.MilliCPU
+	case v1.ResourceMemory:
+		return r.Memory
+	case v1.ResourceEphemeralStorage:
+		return r.EphemeralStorage
+	default:
+		return r.ScalarResources[name]
+	}
+}
+
+// podResourceRequest sums name's requests across pod's containers.
+func podResourceRequest(pod *v1.Pod, name v1.ResourceName) int64 {
+	var total int64
+	for _, container := range pod.Spec.Containers {
+		quantity, ok := container.Resources.Requests[name]
+		if !ok {
+			continue
+		}
+		if name == v1.ResourceCPU {
+			total += quantity.MilliValue()
+		} else {
+			total += quantity.Value()
+		}
+	}
+	return total
+}
+
+// scoreRequestedToCapacityRatio scores nodeInfo by running each configured
+// resource's utilization through cs.shape and weight-averaging the results.
+func (cs *CustomScheduler) scoreRequestedToCapacityRatio(pod *v1.Pod, nodeInfo *framework.NodeInfo) (int64, *framework.Status) {
+	var weightedScoreSum, weightSum int64
+	for _, res := range cs.resources {
+		name := v1.ResourceName(res.Name)
+		allocatable := resourceValue(nodeInfo.Allocatable, name)
+		if allocatable <= 0 {
+			continue
+		}
+		requested := resourceValue(nodeInfo.Requested, name) + podResourceRequest(pod, name)
+		utilization := requested * 100 / allocatable
+		weightedScoreSum += interpolateShape(cs.shape, utilization) * res.Weight
+		weightSum += res.Weight
+	}
+
+	if weightSum == 0 {
+		return 0, framework.NewStatus(framework.Success, "")
+	}
+	return weightedScoreSum / weightSum, framework.NewStatus(framework.Success, "")
+}
@@ -0,0 +1,76 @@
+package plugins
+
+import "testing"
+
+func TestValidateShape(t *testing.T) {
+	cases := []struct {
+		name    string
+		shape   []UtilizationShapePoint
+		wantErr bool
+	}{
+		{"empty", nil, true},
+		{"score out of range", []UtilizationShapePoint{{Utilization: 0, Score: 11}}, true},
+		{"not sorted", []UtilizationShapePoint{{Utilization: 50, Score: 1}, {Utilization: 0, Score: 2}}, true},
+		{"duplicate utilization", []UtilizationShapePoint{{Utilization: 0, Score: 0}, {Utilization: 0, Score: 10}}, true},
+		{"valid", []UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 100, Score: 0}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateShape(c.shape)
+			if (err != nil) != c.wantErr {
+				t.Errorf("validateShape(%v) error = %v, wantErr %v", c.shape, err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	cases := []struct {
+		name      string
+		resources []ResourceSpec
+		wantErr   bool
+	}{
+		{"empty", nil, true},
+		{"non-positive weight", []ResourceSpec{{Name: "cpu", Weight: 0}}, true},
+		{"builtin", []ResourceSpec{{Name: "memory", Weight: 1}}, false},
+		{"extended", []ResourceSpec{{Name: "nvidia.com/gpu", Weight: 1}}, false},
+		{"invalid name", []ResourceSpec{{Name: "not a name!", Weight: 1}}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateResources(c.resources)
+			if (err != nil) != c.wantErr {
+				t.Errorf("validateResources(%v) error = %v, wantErr %v", c.resources, err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestInterpolateShape(t *testing.T) {
+	shape := []UtilizationShapePoint{
+		{Utilization: 0, Score: 10},
+		{Utilization: 50, Score: 5},
+		{Utilization: 100, Score: 0},
+	}
+
+	cases := []struct {
+		utilization int64
+		want        int64
+	}{
+		{-10, 10},
+		{0, 10},
+		{25, 7},
+		{50, 5},
+		{75, 2},
+		{100, 0},
+		{150, 0},
+	}
+
+	for _, c := range cases {
+		if got := interpolateShape(shape, c.utilization); got != c.want {
+			t.Errorf("interpolateShape(shape, %d) = %d, want %d", c.utilization, got, c.want)
+		}
+	}
+}
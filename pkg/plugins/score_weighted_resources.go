@@ -0,0 +1,24 @@
+package plugins
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// scoreWeightedResources sums, across cs.resources, each resource's
+// allocatable-minus-requested amount multiplied by its weight. Least mode
+// negates the result so that emptier nodes score lower.
+func (cs *CustomScheduler) scoreWeightedResources(pod *v1.Pod, nodeInfo *framework.NodeInfo) int64 {
+	var weightedSum int64
+	for _, res := range cs.resources {
+		name := v1.ResourceName(res.Name)
+		allocatable := resourceValue(nodeInfo.Allocatable, name)
+		requested := resourceValue(nodeInfo.Requested, name) + podResourceRequest(pod, name)
+		weightedSum += (allocatable - requested) * res.Weight
+	}
+
+	if cs.scoreMode == leastMode {
+		return -weightedSum
+	}
+	return weightedSum
+}
@@ -0,0 +1,34 @@
+package plugins
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestScoreWeightedResources(t *testing.T) {
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.Allocatable = &framework.Resource{Memory: 1000, ScalarResources: map[v1.ResourceName]int64{"nvidia.com/gpu": 4}}
+	nodeInfo.Requested = &framework.Resource{Memory: 200, ScalarResources: map[v1.ResourceName]int64{"nvidia.com/gpu": 1}}
+	pod := &v1.Pod{}
+
+	cs := &CustomScheduler{
+		scoreMode: mostMode,
+		resources: []ResourceSpec{
+			{Name: "memory", Weight: 1},
+			{Name: "nvidia.com/gpu", Weight: 10},
+		},
+	}
+
+	// free memory (800) * 1 + free gpu (3) * 10 = 830
+	want := int64(830)
+	if got := cs.scoreWeightedResources(pod, nodeInfo); got != want {
+		t.Errorf("scoreWeightedResources() = %d, want %d", got, want)
+	}
+
+	cs.scoreMode = leastMode
+	if got := cs.scoreWeightedResources(pod, nodeInfo); got != -want {
+		t.Errorf("scoreWeightedResources() in least mode = %d, want %d", got, -want)
+	}
+}